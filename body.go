@@ -0,0 +1,62 @@
+package httplogx
+
+import "strings"
+
+// defaultBodyContentTypes is used when Options.BodyContentTypes is unset,
+// so binary payloads aren't pulled into logs by default.
+var defaultBodyContentTypes = []string{
+	"application/json",
+	"application/x-www-form-urlencoded",
+	"text/plain",
+}
+
+// bodyCapture carries the request/response bodies captured for a single
+// request through to RequestLoggerEntry.Write via its extra parameter.
+type bodyCapture struct {
+	RequestBody       []byte
+	RequestTruncated  bool
+	ResponseBody      []byte
+	ResponseTruncated bool
+}
+
+func isLoggableContentType(contentType string, allowed []string) bool {
+	if idx := strings.IndexByte(contentType, ';'); idx != -1 {
+		contentType = contentType[:idx]
+	}
+	contentType = strings.ToLower(strings.TrimSpace(contentType))
+	if contentType == "" {
+		return false
+	}
+
+	if len(allowed) == 0 {
+		allowed = defaultBodyContentTypes
+	}
+	for _, a := range allowed {
+		if contentType == a {
+			return true
+		}
+	}
+	return false
+}
+
+func truncateBody(body []byte, max int) ([]byte, bool) {
+	if max > 0 && len(body) > max {
+		return body[:max], true
+	}
+	return body, false
+}
+
+func redactBody(contentType string, body []byte, redactors []func(contentType string, body []byte) []byte) []byte {
+	for _, redact := range redactors {
+		body = redact(contentType, body)
+	}
+	return body
+}
+
+func bodyLogField(body []byte, truncated bool) map[string]interface{} {
+	field := map[string]interface{}{"content": string(body)}
+	if truncated {
+		field["truncated"] = true
+	}
+	return field
+}
@@ -0,0 +1,146 @@
+package httplogx
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"runtime/debug"
+
+	"github.com/go-chi/chi/v5/middleware"
+)
+
+// Error is a canonical structured error response. Use the BadRequest,
+// NotFound, Internal, ... helpers to construct one, and WriteError to send
+// it, so every handler in a service produces the same JSON error shape and
+// the same request_id correlation with its log line.
+type Error struct {
+	Status  int         `json:"-"`
+	Code    string      `json:"code,omitempty"`
+	Message string      `json:"message"`
+	Details interface{} `json:"details,omitempty"`
+
+	cause error
+	stack []byte
+}
+
+func (e *Error) Error() string {
+	return e.Message
+}
+
+func (e *Error) Unwrap() error {
+	return e.cause
+}
+
+// WithDetails attaches arbitrary structured details to the error response,
+// e.g. per-field validation failures.
+func (e *Error) WithDetails(details interface{}) *Error {
+	clone := *e
+	clone.Details = details
+	return &clone
+}
+
+// WithCause wraps err, capturing a stack trace at the call site so
+// WriteError can enrich the request's log entry with it. The cause itself
+// is never included in the JSON response sent to the client.
+func (e *Error) WithCause(err error) *Error {
+	clone := *e
+	clone.cause = err
+	clone.stack = debug.Stack()
+	return &clone
+}
+
+// BadRequest builds a 400 Error.
+func BadRequest(message string) *Error {
+	return &Error{Status: http.StatusBadRequest, Code: "bad_request", Message: message}
+}
+
+// Unauthorized builds a 401 Error.
+func Unauthorized(message string) *Error {
+	return &Error{Status: http.StatusUnauthorized, Code: "unauthorized", Message: message}
+}
+
+// Forbidden builds a 403 Error.
+func Forbidden(message string) *Error {
+	return &Error{Status: http.StatusForbidden, Code: "forbidden", Message: message}
+}
+
+// NotFound builds a 404 Error.
+func NotFound(message string) *Error {
+	return &Error{Status: http.StatusNotFound, Code: "not_found", Message: message}
+}
+
+// Conflict builds a 409 Error.
+func Conflict(message string) *Error {
+	return &Error{Status: http.StatusConflict, Code: "conflict", Message: message}
+}
+
+// Internal builds a 500 Error.
+func Internal(message string) *Error {
+	return &Error{Status: http.StatusInternalServerError, Code: "internal", Message: message}
+}
+
+// errorResponse is the JSON body WriteError sends, carrying the request_id
+// alongside the Error's own fields.
+type errorResponse struct {
+	RequestID string      `json:"request_id,omitempty"`
+	Code      string      `json:"code,omitempty"`
+	Message   string      `json:"message"`
+	Details   interface{} `json:"details,omitempty"`
+}
+
+// WriteError writes err as a canonical JSON error response including the
+// request's request_id, and enriches the current RequestLoggerEntry (see
+// LogEntrySetFields) with error.code, error.message and, if err wraps a
+// cause via Error.WithCause, error.cause and a stack trace. Errors that
+// aren't an *Error are reported to the client as a generic 500 Internal
+// Server Error, with the original error preserved as error.cause on the
+// log entry.
+func WriteError(w http.ResponseWriter, r *http.Request, err error) {
+	httpErr := asError(err)
+
+	fields := map[string]interface{}{
+		"error.code":    httpErr.Code,
+		"error.message": httpErr.Message,
+	}
+	if httpErr.cause != nil {
+		fields["error.cause"] = httpErr.cause.Error()
+	}
+	if httpErr.stack != nil {
+		fields["error.stacktrace"] = string(httpErr.stack)
+	}
+	LogEntrySetFields(r.Context(), fields)
+
+	resp := errorResponse{
+		RequestID: middleware.GetReqID(r.Context()),
+		Code:      httpErr.Code,
+		Message:   httpErr.Message,
+		Details:   httpErr.Details,
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(httpErr.Status)
+	_ = json.NewEncoder(w).Encode(resp)
+}
+
+func asError(err error) *Error {
+	var httpErr *Error
+	if errors.As(err, &httpErr) {
+		return httpErr
+	}
+	return Internal("internal server error").WithCause(err)
+}
+
+// HandlerFunc is an http handler that returns an error instead of writing
+// one itself, letting a service write `return httplogx.NotFound(...)`
+// instead of calling WriteError inline in every handler.
+type HandlerFunc func(w http.ResponseWriter, r *http.Request) error
+
+// ErrorHandler adapts fn to an http.Handler, writing any error it returns
+// via WriteError.
+func ErrorHandler(fn HandlerFunc) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if err := fn(w, r); err != nil {
+			WriteError(w, r, err)
+		}
+	})
+}
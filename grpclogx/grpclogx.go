@@ -0,0 +1,223 @@
+// Package grpclogx mirrors httplogx's structured request/response logging
+// for gRPC services. It reuses httplogx.Options, httplogx.LogSink and the
+// LogEntry/LogEntrySetField context helpers, so a service that speaks both
+// HTTP and gRPC gets one uniform log schema regardless of transport.
+package grpclogx
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"runtime/debug"
+	"time"
+
+	"github.com/go-chi/chi/v5/middleware"
+	"github.com/pcriv/httplogx"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// requestIDMetadataKey is the gRPC metadata key used to propagate a
+// request id across services, mirroring chi's X-Request-Id HTTP header.
+const requestIDMetadataKey = "x-request-id"
+
+// UnaryServerInterceptor logs unary RPCs through sink, using the same
+// "http_request"/"http_response" field schema, status-derived log levels
+// and panic recovery as httplogx.Handler.
+func UnaryServerInterceptor(sink httplogx.LogSink, opts ...httplogx.Options) grpc.UnaryServerInterceptor {
+	o := configure(opts)
+
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (resp interface{}, err error) {
+		ctx, entry := newLogEntry(ctx, sink, o, info.FullMethod)
+		t1 := time.Now()
+
+		defer func() {
+			if v := recover(); v != nil {
+				entry.panic(v)
+				err = status.Error(codes.Internal, fmt.Sprintf("%+v", v))
+			}
+			entry.write(status.Code(err), time.Since(t1))
+		}()
+
+		return handler(ctx, req)
+	}
+}
+
+// StreamServerInterceptor is the streaming counterpart of
+// UnaryServerInterceptor, logging once the stream completes.
+func StreamServerInterceptor(sink httplogx.LogSink, opts ...httplogx.Options) grpc.StreamServerInterceptor {
+	o := configure(opts)
+
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) (err error) {
+		ctx, entry := newLogEntry(ss.Context(), sink, o, info.FullMethod)
+		t1 := time.Now()
+
+		defer func() {
+			if v := recover(); v != nil {
+				entry.panic(v)
+				err = status.Error(codes.Internal, fmt.Sprintf("%+v", v))
+			}
+			entry.write(status.Code(err), time.Since(t1))
+		}()
+
+		return handler(srv, &loggingServerStream{ServerStream: ss, ctx: ctx})
+	}
+}
+
+func configure(opts []httplogx.Options) httplogx.Options {
+	if len(opts) > 0 {
+		return httplogx.Configure(opts[0])
+	}
+	return httplogx.Configure(httplogx.Options{})
+}
+
+// loggingServerStream overrides ServerStream.Context so the log entry
+// stashed on ctx by newLogEntry is reachable from LogEntrySink and
+// LogEntrySetField inside the handler.
+type loggingServerStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *loggingServerStream) Context() context.Context {
+	return s.ctx
+}
+
+// logEntry carries the per-call sink and method name between the
+// interceptor's handler invocation and its deferred write.
+type logEntry struct {
+	*httplogx.RequestLoggerEntry
+	method string
+}
+
+func newLogEntry(ctx context.Context, sink httplogx.LogSink, opts httplogx.Options, method string) (context.Context, *logEntry) {
+	reqID := requestID(ctx)
+
+	// Reuse httplogx's "http_request"/"http_response" field names (rather
+	// than "grpc_request"/"grpc_response") so HTTP and gRPC log lines share
+	// one schema and can be queried/dashboarded together.
+	fields := map[string]interface{}{
+		"http_request": map[string]interface{}{
+			"method":     method,
+			"request_id": reqID,
+			"metadata":   metadataLogField(ctx, opts.SkipHeaders),
+		},
+	}
+
+	rle := &httplogx.RequestLoggerEntry{Sink: sink.With(fields)}
+	if opts.Concise {
+		rle.Sink.Log(httplogx.LevelInfo, fmt.Sprintf("Request: %s", method))
+	}
+
+	ctx = context.WithValue(ctx, middleware.LogEntryCtxKey, rle)
+	return ctx, &logEntry{RequestLoggerEntry: rle, method: method}
+}
+
+func (e *logEntry) write(code codes.Code, elapsed time.Duration) {
+	msg := fmt.Sprintf("Response: %s %s - %s", code, codeLabel(code), e.method)
+
+	responseLog := map[string]interface{}{
+		"code":    code.String(),
+		"elapsed": float64(elapsed.Nanoseconds()) / 1000000.0, // in milliseconds
+	}
+
+	e.Sink.With(map[string]interface{}{"http_response": responseLog}).Log(codeLevel(code), msg)
+}
+
+func (e *logEntry) panic(v interface{}) {
+	e.Sink = e.Sink.With(map[string]interface{}{
+		"stacktrace": string(debug.Stack()),
+		"panic":      fmt.Sprintf("%+v", v),
+	})
+}
+
+// requestID returns the incoming x-request-id metadata value, generating a
+// random one if the caller didn't send it.
+func requestID(ctx context.Context) string {
+	if md, ok := metadata.FromIncomingContext(ctx); ok {
+		if ids := md.Get(requestIDMetadataKey); len(ids) > 0 && ids[0] != "" {
+			return ids[0]
+		}
+	}
+
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return ""
+	}
+	return hex.EncodeToString(b)
+}
+
+// metadataLogField redacts incoming gRPC metadata the same way
+// httplogx redacts HTTP headers: authorization and cookie-like keys are
+// always masked, plus any key named in skipList.
+func metadataLogField(ctx context.Context, skipList []string) map[string]string {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return map[string]string{}
+	}
+
+	field := map[string]string{}
+	for k, v := range md {
+		switch {
+		case len(v) == 0:
+			continue
+		case len(v) == 1:
+			field[k] = v[0]
+		default:
+			field[k] = fmt.Sprintf("%v", v)
+		}
+
+		if k == "authorization" || k == "cookie" || k == "set-cookie" {
+			field[k] = "***"
+		}
+		for _, skip := range skipList {
+			if k == skip {
+				field[k] = "***"
+				break
+			}
+		}
+	}
+	return field
+}
+
+// codeLevel maps a gRPC status code to the same Level scale httplogx uses
+// for HTTP status codes: OK-ish codes are Info, client-caused codes are
+// Warn, and server-caused codes are Error.
+func codeLevel(code codes.Code) httplogx.Level {
+	switch code {
+	case codes.OK:
+		return httplogx.LevelInfo
+	case codes.Canceled, codes.InvalidArgument, codes.DeadlineExceeded, codes.NotFound,
+		codes.AlreadyExists, codes.PermissionDenied, codes.Unauthenticated,
+		codes.FailedPrecondition, codes.Aborted, codes.OutOfRange, codes.ResourceExhausted:
+		return httplogx.LevelWarn
+	case codes.Unknown, codes.Unimplemented, codes.Internal, codes.Unavailable, codes.DataLoss:
+		return httplogx.LevelError
+	default:
+		return httplogx.LevelInfo
+	}
+}
+
+// codeLabel gives a short human label for a gRPC status code, analogous to
+// httplogx's statusLabel for HTTP status codes.
+func codeLabel(code codes.Code) string {
+	switch code {
+	case codes.OK:
+		return "OK"
+	case codes.Canceled:
+		return "Canceled"
+	case codes.Unauthenticated:
+		return "Unauthenticated"
+	case codes.PermissionDenied:
+		return "Permission Denied"
+	case codes.NotFound:
+		return "Not Found"
+	case codes.Unknown, codes.Unimplemented, codes.Internal, codes.Unavailable, codes.DataLoss:
+		return "Server Error"
+	default:
+		return "Client Error"
+	}
+}
@@ -1,8 +1,10 @@
 package httplogx
 
 import (
+	"bytes"
 	"context"
 	"fmt"
+	"io"
 	"io/ioutil"
 	"net/http"
 	"strings"
@@ -13,9 +15,9 @@ import (
 	"github.com/rs/zerolog"
 )
 
-func newRequestLogger(logger zerolog.Logger, opts ...Options) *requestLogger {
+func newRequestLogger(sink LogSink, opts ...Options) *requestLogger {
 	reqLogger := requestLogger{
-		Logger: logger,
+		Sink: sink,
 	}
 	if len(opts) > 0 {
 		reqLogger.opts = Configure(opts[0])
@@ -23,35 +25,83 @@ func newRequestLogger(logger zerolog.Logger, opts ...Options) *requestLogger {
 	return &reqLogger
 }
 
-// RequestLogger is an http middleware to log http requests and responses.
+// RequestLogger is an http middleware to log http requests and responses,
+// using zerolog as the underlying log sink.
 //
 // NOTE: for simplicity, RequestLogger automatically makes use of the chi RequestID and
 // Recoverer middleware.
 func RequestLogger(logger zerolog.Logger, opts ...Options) func(next http.Handler) http.Handler {
+	return RequestLoggerWithSink(NewZerologSink(logger), opts...)
+}
+
+// RequestLoggerWithSink is an http middleware to log http requests and
+// responses through an arbitrary LogSink, so users who've standardized on
+// log/slog, zap or logrus can adopt httplogx without dragging in zerolog.
+//
+// NOTE: for simplicity, RequestLoggerWithSink automatically makes use of the
+// chi RequestID and Recoverer middleware.
+func RequestLoggerWithSink(sink LogSink, opts ...Options) func(next http.Handler) http.Handler {
 	return chi.Chain(
 		middleware.RequestID,
-		Handler(logger, opts...),
+		Handler(sink, opts...),
 		middleware.Recoverer,
 	).Handler
 }
 
-func Handler(logger zerolog.Logger, opts ...Options) func(next http.Handler) http.Handler {
-	var f middleware.LogFormatter = newRequestLogger(logger, opts...)
+func Handler(sink LogSink, opts ...Options) func(next http.Handler) http.Handler {
+	var o Options
+	if len(opts) > 0 {
+		o = Configure(opts[0])
+	} else {
+		o = Configure(Options{})
+	}
+
+	f := newRequestLogger(sink, o)
 	return func(next http.Handler) http.Handler {
 		fn := func(w http.ResponseWriter, r *http.Request) {
 			entry := f.NewLogEntry(r)
 			ww := middleware.NewWrapResponseWriter(w, r.ProtoMajor)
 
-			buf := newLimitBuffer(512)
+			var reqBody []byte
+			if o.LogRequestBody && r.Body != nil {
+				// Cap what we read into memory for logging at MaxBodyBytes+1
+				// (the +1 just lets truncateBody detect truncation below);
+				// the rest of the body is still streamed to the handler
+				// untouched via the MultiReader, same as the response side's
+				// limitBuffer cap.
+				reqBody, _ = io.ReadAll(io.LimitReader(r.Body, int64(o.MaxBodyBytes)+1))
+				r.Body = io.NopCloser(io.MultiReader(bytes.NewReader(reqBody), r.Body))
+			}
+
+			// +1 so truncateBody below can detect truncation the same way
+			// it does for the request body: by seeing more bytes than
+			// MaxBodyBytes came back.
+			buf := newLimitBuffer(o.MaxBodyBytes + 1)
 			ww.Tee(buf)
 
 			t1 := time.Now()
 			defer func() {
-				var respBody []byte
-				if ww.Status() >= 400 {
-					respBody, _ = ioutil.ReadAll(buf)
+				status := ww.Status()
+				elapsed := time.Since(t1)
+
+				if o.Sampler != nil && !o.Sampler.Sample(r, status, elapsed) {
+					return
 				}
-				entry.Write(ww.Status(), ww.BytesWritten(), ww.Header(), time.Since(t1), respBody)
+
+				var capture bodyCapture
+
+				if reqBody != nil && isLoggableContentType(r.Header.Get("Content-Type"), o.BodyContentTypes) {
+					capture.RequestBody, capture.RequestTruncated = truncateBody(reqBody, o.MaxBodyBytes)
+					capture.RequestBody = redactBody(r.Header.Get("Content-Type"), capture.RequestBody, o.BodyRedactors)
+				}
+
+				if (o.LogResponseBody || (status >= 400 && o.Concise)) && isLoggableContentType(ww.Header().Get("Content-Type"), o.BodyContentTypes) {
+					respBody, _ := ioutil.ReadAll(buf)
+					capture.ResponseBody, capture.ResponseTruncated = truncateBody(respBody, o.MaxBodyBytes)
+					capture.ResponseBody = redactBody(ww.Header().Get("Content-Type"), capture.ResponseBody, o.BodyRedactors)
+				}
+
+				entry.Write(status, ww.BytesWritten(), ww.Header(), elapsed, capture)
 			}()
 
 			next.ServeHTTP(ww, middleware.WithLogEntry(r, entry))
@@ -61,28 +111,49 @@ func Handler(logger zerolog.Logger, opts ...Options) func(next http.Handler) htt
 }
 
 type requestLogger struct {
-	Logger zerolog.Logger
-	opts   Options
+	Sink LogSink
+	opts Options
 }
 
 func (l *requestLogger) NewLogEntry(r *http.Request) middleware.LogEntry {
 	msg := fmt.Sprintf("Request: %s %s", r.Method, r.URL.Path)
+	fields := requestLogFields(r, l.opts.SkipHeaders)
+	for k, v := range traceFields(r, l.opts.TraceProvider) {
+		fields[k] = v
+	}
+
 	entry := &RequestLoggerEntry{
-		Logger: l.Logger.With().Fields(requestLogFields(r, l.opts.SkipHeaders)).Logger(),
+		Sink: l.Sink.With(fields),
+		opts: l.opts,
 	}
 	if l.opts.Concise {
-		entry.Logger.Info().Msgf(msg)
+		if l.opts.Sampler != nil {
+			// The Sampler only sees the final status/elapsed, so we can't
+			// decide yet whether this request will be logged at all.
+			// Defer the concise "Request: ..." line until Write, which is
+			// only reached once the Sampler has kept the request.
+			entry.pendingRequestMsg = msg
+		} else {
+			entry.Sink.Log(LevelInfo, msg)
+		}
 	}
 	return entry
 }
 
 type RequestLoggerEntry struct {
-	Logger zerolog.Logger
-	msg    string
-	opts   Options
+	Sink LogSink
+	msg  string
+	opts Options
+
+	pendingRequestMsg string
 }
 
 func (l *RequestLoggerEntry) Write(status, bytes int, header http.Header, elapsed time.Duration, extra interface{}) {
+	if l.pendingRequestMsg != "" {
+		l.Sink.Log(LevelInfo, l.pendingRequestMsg)
+		l.pendingRequestMsg = ""
+	}
+
 	msg := fmt.Sprintf("Response: %d %s", status, statusLabel(status))
 	if l.msg != "" {
 		msg = fmt.Sprintf("%s - %s", msg, l.msg)
@@ -94,21 +165,21 @@ func (l *RequestLoggerEntry) Write(status, bytes int, header http.Header, elapse
 		"elapsed": float64(elapsed.Nanoseconds()) / 1000000.0, // in milliseconds
 	}
 
-	if l.opts.Concise {
-		// Include response header, as well for error status codes (>400) we include
-		// the response body so we may inspect the log message sent back to the client.
-		if status >= 400 {
-			body, _ := extra.([]byte)
-			responseLog["body"] = string(body)
-		}
-		if len(header) > 0 {
-			responseLog["header"] = headerLogField(header, l.opts.SkipHeaders)
-		}
+	if l.opts.Concise && len(header) > 0 {
+		responseLog["header"] = headerLogField(header, l.opts.SkipHeaders)
+	}
+
+	capture, _ := extra.(bodyCapture)
+	if capture.ResponseBody != nil {
+		responseLog["body"] = bodyLogField(capture.ResponseBody, capture.ResponseTruncated)
+	}
+
+	fields := map[string]interface{}{"http_response": responseLog}
+	if capture.RequestBody != nil {
+		fields["request_body"] = bodyLogField(capture.RequestBody, capture.RequestTruncated)
 	}
 
-	l.Logger.WithLevel(statusLevel(status)).Fields(map[string]interface{}{
-		"http_response": responseLog,
-	}).Msgf(msg)
+	l.Sink.With(fields).Log(statusLevel(status), msg)
 }
 
 func (l *RequestLoggerEntry) Panic(v interface{}, stack []byte) {
@@ -117,10 +188,10 @@ func (l *RequestLoggerEntry) Panic(v interface{}, stack []byte) {
 		stacktrace = string(stack)
 	}
 
-	l.Logger = l.Logger.With().
-		Str("stacktrace", stacktrace).
-		Str("panic", fmt.Sprintf("%+v", v)).
-		Logger()
+	l.Sink = l.Sink.With(map[string]interface{}{
+		"stacktrace": stacktrace,
+		"panic":      fmt.Sprintf("%+v", v),
+	})
 
 	l.msg = fmt.Sprintf("%+v", v)
 
@@ -184,18 +255,18 @@ func headerLogField(header http.Header, skipList []string) map[string]string {
 	return headerField
 }
 
-func statusLevel(status int) zerolog.Level {
+func statusLevel(status int) Level {
 	switch {
 	case status <= 0:
-		return zerolog.WarnLevel
+		return LevelWarn
 	case status < 400: // for codes in 100s, 200s, 300s
-		return zerolog.InfoLevel
+		return LevelInfo
 	case status >= 400 && status < 500:
-		return zerolog.WarnLevel
+		return LevelWarn
 	case status >= 500:
-		return zerolog.ErrorLevel
+		return LevelError
 	default:
-		return zerolog.InfoLevel
+		return LevelInfo
 	}
 }
 
@@ -225,19 +296,30 @@ func LogEntry(ctx context.Context) zerolog.Logger {
 	entry, ok := ctx.Value(middleware.LogEntryCtxKey).(*RequestLoggerEntry)
 	if !ok || entry == nil {
 		return zerolog.Nop()
-	} else {
-		return entry.Logger
 	}
+	if sink, ok := entry.Sink.(*zerologSink); ok {
+		return sink.logger
+	}
+	return zerolog.Nop()
 }
 
-func LogEntrySetField(ctx context.Context, key, value string) {
-	if entry, ok := ctx.Value(middleware.LogEntryCtxKey).(*RequestLoggerEntry); ok {
-		entry.Logger = entry.Logger.With().Str(key, value).Logger()
+// LogEntrySink returns the LogSink for the current request, working for any
+// backend (zerolog, slog, zap, logrus, ...), unlike LogEntry which is
+// zerolog-specific and kept only for backward compatibility.
+func LogEntrySink(ctx context.Context) LogSink {
+	entry, ok := ctx.Value(middleware.LogEntryCtxKey).(*RequestLoggerEntry)
+	if !ok || entry == nil {
+		return &zerologSink{logger: zerolog.Nop()}
 	}
+	return entry.Sink
+}
+
+func LogEntrySetField(ctx context.Context, key, value string) {
+	LogEntrySetFields(ctx, map[string]interface{}{key: value})
 }
 
 func LogEntrySetFields(ctx context.Context, fields map[string]interface{}) {
 	if entry, ok := ctx.Value(middleware.LogEntryCtxKey).(*RequestLoggerEntry); ok {
-		entry.Logger = entry.Logger.With().Fields(fields).Logger()
+		entry.Sink = entry.Sink.With(fields)
 	}
 }
@@ -0,0 +1,26 @@
+package httplogx
+
+import "bytes"
+
+// limitBuffer is a bytes.Buffer that only retains the first n bytes written
+// to it, discarding the rest. It's used to cap how much of a response body
+// we hold in memory for logging purposes.
+type limitBuffer struct {
+	*bytes.Buffer
+	limit int
+}
+
+func newLimitBuffer(size int) *limitBuffer {
+	return &limitBuffer{Buffer: &bytes.Buffer{}, limit: size}
+}
+
+func (b *limitBuffer) Write(p []byte) (n int, err error) {
+	if b.Buffer.Len() >= b.limit {
+		return len(p), nil
+	}
+	limit := b.limit - b.Buffer.Len()
+	if limit > len(p) {
+		limit = len(p)
+	}
+	return b.Buffer.Write(p[:limit])
+}
@@ -0,0 +1,123 @@
+package httplogx
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/chi/v5/middleware"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/rs/zerolog"
+)
+
+// MetricsOptions controls the behavior of Metrics.
+type MetricsOptions struct {
+	// DurationBuckets overrides the default histogram buckets (in seconds)
+	// used for http_request_duration_seconds. Defaults to prometheus.DefBuckets.
+	DurationBuckets []float64
+}
+
+type metricsCollector struct {
+	requestsTotal    *prometheus.CounterVec
+	requestDuration  *prometheus.HistogramVec
+	requestSize      *prometheus.HistogramVec
+	responseSize     *prometheus.HistogramVec
+	requestsInFlight prometheus.Gauge
+}
+
+func newMetricsCollector(reg prometheus.Registerer, opts MetricsOptions) *metricsCollector {
+	buckets := opts.DurationBuckets
+	if len(buckets) == 0 {
+		buckets = prometheus.DefBuckets
+	}
+
+	c := &metricsCollector{
+		requestsTotal: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "http_requests_total",
+			Help: "Total number of HTTP requests processed.",
+		}, []string{"method", "route", "status"}),
+		requestDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Name:    "http_request_duration_seconds",
+			Help:    "HTTP request duration in seconds.",
+			Buckets: buckets,
+		}, []string{"method", "route", "status"}),
+		requestSize: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Name: "http_request_size_bytes",
+			Help: "HTTP request body size in bytes.",
+		}, []string{"method", "route"}),
+		responseSize: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Name: "http_response_size_bytes",
+			Help: "HTTP response body size in bytes.",
+		}, []string{"method", "route", "status"}),
+		requestsInFlight: prometheus.NewGauge(prometheus.GaugeOpts{
+			Name: "http_requests_in_flight",
+			Help: "Number of HTTP requests currently being served.",
+		}),
+	}
+
+	reg.MustRegister(c.requestsTotal, c.requestDuration, c.requestSize, c.responseSize, c.requestsInFlight)
+	return c
+}
+
+// Metrics is a sibling middleware to RequestLogger that records Prometheus
+// metrics for every request, labeled by the chi route pattern rather than
+// the raw path so dynamic segments don't cause a cardinality blowup.
+func Metrics(reg prometheus.Registerer, opts ...MetricsOptions) func(next http.Handler) http.Handler {
+	var o MetricsOptions
+	if len(opts) > 0 {
+		o = opts[0]
+	}
+	c := newMetricsCollector(reg, o)
+
+	return func(next http.Handler) http.Handler {
+		fn := func(w http.ResponseWriter, r *http.Request) {
+			c.requestsInFlight.Inc()
+			defer c.requestsInFlight.Dec()
+
+			ww := middleware.NewWrapResponseWriter(w, r.ProtoMajor)
+
+			t1 := time.Now()
+			defer func() {
+				route := routePattern(r)
+				status := strconv.Itoa(ww.Status())
+
+				c.requestsTotal.WithLabelValues(r.Method, route, status).Inc()
+				c.requestDuration.WithLabelValues(r.Method, route, status).Observe(time.Since(t1).Seconds())
+				if r.ContentLength >= 0 {
+					// ContentLength is -1 when unknown, e.g. chunked-encoding
+					// requests with no Content-Length header.
+					c.requestSize.WithLabelValues(r.Method, route).Observe(float64(r.ContentLength))
+				}
+				c.responseSize.WithLabelValues(r.Method, route, status).Observe(float64(ww.BytesWritten()))
+			}()
+
+			next.ServeHTTP(ww, r)
+		}
+		return http.HandlerFunc(fn)
+	}
+}
+
+func routePattern(r *http.Request) string {
+	if rc := chi.RouteContext(r.Context()); rc != nil {
+		if pattern := rc.RoutePattern(); pattern != "" {
+			return pattern
+		}
+	}
+	return "unmatched"
+}
+
+// Chain wires RequestID, Metrics, RequestLogger and Recoverer in the
+// recommended order, closing the common gap where users bolt httplogx
+// logging on and then hand-roll their own Prometheus counters. opts
+// configures the RequestLogger stage (Concise, Sampler, body capture,
+// TraceProvider, ...) the same way it would if calling Handler directly;
+// pass Options{} to use the defaults.
+func Chain(logger zerolog.Logger, reg prometheus.Registerer, opts Options, metricsOpts ...MetricsOptions) func(next http.Handler) http.Handler {
+	return chi.Chain(
+		middleware.RequestID,
+		Metrics(reg, metricsOpts...),
+		Handler(NewZerologSink(logger), opts),
+		middleware.Recoverer,
+	).Handler
+}
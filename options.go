@@ -0,0 +1,57 @@
+package httplogx
+
+// Options controls the behavior of RequestLogger/RequestLoggerWithSink.
+type Options struct {
+	// JSON enables structured JSON output for panic stack traces. When
+	// false, panics are additionally pretty-printed to stderr.
+	JSON bool
+	// Concise logs a single "Request: ..." line at the start of the
+	// request in addition to the "Response: ..." line, and includes the
+	// response headers (and, for status codes >= 400, the response body)
+	// on the response line.
+	Concise bool
+	// SkipHeaders lists header names to redact in logged header fields, in
+	// addition to the always-redacted Authorization, Cookie and Set-Cookie
+	// headers. Header names are lower-cased before being compared, so
+	// entries must be given in lower case to match.
+	SkipHeaders []string
+
+	// LogRequestBody captures the request body regardless of the response
+	// status, subject to BodyContentTypes filtering.
+	LogRequestBody bool
+	// LogResponseBody captures the response body regardless of the
+	// response status, subject to BodyContentTypes filtering. When unset,
+	// response bodies for status codes >= 400 are still captured, but
+	// only attached to the log line when Concise is also set, matching
+	// RequestLogger's original error-body behavior.
+	LogResponseBody bool
+	// MaxBodyBytes caps how many bytes of a request/response body are
+	// captured for logging. Defaults to 512.
+	MaxBodyBytes int
+	// BodyContentTypes restricts body capture to these content types
+	// (matched against the media type, ignoring parameters). Defaults to
+	// a small allow-list of textual content types, skipping binary ones.
+	BodyContentTypes []string
+	// BodyRedactors run in order over a captured body before it's logged,
+	// letting callers strip sensitive fields such as passwords or card
+	// numbers.
+	BodyRedactors []func(contentType string, body []byte) []byte
+
+	// TraceProvider extracts trace_id/span_id/sampled from the request
+	// context, e.g. from an OpenTelemetry span. When nil, httplogx falls
+	// back to parsing the W3C "traceparent" header.
+	TraceProvider TraceProvider
+
+	// Sampler decides whether a request's log line is written at all. When
+	// nil, every request is logged. Sampled-out requests still flow
+	// through Metrics and trace extraction.
+	Sampler Sampler
+}
+
+// Configure applies defaults to opts and returns the resulting Options.
+func Configure(opts Options) Options {
+	if opts.MaxBodyBytes <= 0 {
+		opts.MaxBodyBytes = 512
+	}
+	return opts
+}
@@ -0,0 +1,112 @@
+package httplogx
+
+import (
+	"net/http"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// Sampler decides whether a given request should be logged, letting
+// high-QPS services keep httplogx enabled in production without drowning
+// their log pipeline. Sampled-out requests still flow through Metrics and
+// trace extraction; only the log call itself is skipped.
+type Sampler interface {
+	Sample(r *http.Request, status int, elapsed time.Duration) bool
+}
+
+// NewRateSampler logs 1 in n successful (non-error) requests; 4xx/5xx
+// responses are always logged.
+func NewRateSampler(n int) Sampler {
+	if n < 1 {
+		n = 1
+	}
+	return &rateSampler{n: int64(n)}
+}
+
+type rateSampler struct {
+	n       int64
+	counter int64
+}
+
+func (s *rateSampler) Sample(r *http.Request, status int, elapsed time.Duration) bool {
+	if status >= 400 {
+		return true
+	}
+	return atomic.AddInt64(&s.counter, 1)%s.n == 0
+}
+
+// NewBurstSampler caps logging to perSecond lines per second using a
+// token-bucket, always allowing 4xx/5xx responses through regardless of the
+// bucket's state.
+func NewBurstSampler(perSecond int) Sampler {
+	if perSecond < 1 {
+		perSecond = 1
+	}
+	return &burstSampler{
+		capacity: float64(perSecond),
+		tokens:   float64(perSecond),
+		last:     time.Now(),
+	}
+}
+
+type burstSampler struct {
+	mu       sync.Mutex
+	capacity float64
+	tokens   float64
+	last     time.Time
+}
+
+func (s *burstSampler) Sample(r *http.Request, status int, elapsed time.Duration) bool {
+	if status >= 400 {
+		return true
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := time.Now()
+	s.tokens += now.Sub(s.last).Seconds() * s.capacity
+	if s.tokens > s.capacity {
+		s.tokens = s.capacity
+	}
+	s.last = now
+
+	if s.tokens < 1 {
+		return false
+	}
+	s.tokens--
+	return true
+}
+
+// defaultAdaptiveSlowThreshold is the elapsed time above which a request is
+// considered "slow" and always logged by an adaptive sampler.
+const defaultAdaptiveSlowThreshold = 1 * time.Second
+
+// NewAdaptiveSampler always logs 4xx/5xx responses and requests slower than
+// defaultAdaptiveSlowThreshold, but samples fast 2xx/3xx traffic at
+// sampleRate (a fraction between 0 and 1).
+func NewAdaptiveSampler(sampleRate float64) Sampler {
+	if sampleRate <= 0 {
+		sampleRate = 1
+	}
+	return &adaptiveSampler{sampleRate: sampleRate, slowThreshold: defaultAdaptiveSlowThreshold}
+}
+
+type adaptiveSampler struct {
+	sampleRate    float64
+	slowThreshold time.Duration
+	counter       int64
+}
+
+func (s *adaptiveSampler) Sample(r *http.Request, status int, elapsed time.Duration) bool {
+	if status >= 400 || elapsed > s.slowThreshold || s.sampleRate >= 1 {
+		return true
+	}
+
+	n := int64(1 / s.sampleRate)
+	if n < 1 {
+		n = 1
+	}
+	return atomic.AddInt64(&s.counter, 1)%n == 0
+}
@@ -0,0 +1,108 @@
+package httplogx
+
+import (
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestRateSampler(t *testing.T) {
+	s := NewRateSampler(3)
+	r := &http.Request{}
+
+	var sampled int
+	for i := 0; i < 9; i++ {
+		if s.Sample(r, http.StatusOK, 0) {
+			sampled++
+		}
+	}
+	if sampled != 3 {
+		t.Fatalf("got %d sampled out of 9 at rate 3, want 3", sampled)
+	}
+
+	if !s.Sample(r, http.StatusInternalServerError, 0) {
+		t.Fatal("5xx responses must always be sampled")
+	}
+}
+
+func TestRateSamplerMinimum(t *testing.T) {
+	s := NewRateSampler(0)
+	r := &http.Request{}
+	for i := 0; i < 3; i++ {
+		if !s.Sample(r, http.StatusOK, 0) {
+			t.Fatal("n < 1 should be treated as 1, logging every request")
+		}
+	}
+}
+
+func TestBurstSamplerCapsToCapacity(t *testing.T) {
+	s := NewBurstSampler(2)
+	r := &http.Request{}
+
+	if !s.Sample(r, http.StatusOK, 0) {
+		t.Fatal("first request within burst capacity should be sampled")
+	}
+	if !s.Sample(r, http.StatusOK, 0) {
+		t.Fatal("second request within burst capacity should be sampled")
+	}
+	if s.Sample(r, http.StatusOK, 0) {
+		t.Fatal("third request should exceed the burst capacity and be dropped")
+	}
+	if !s.Sample(r, http.StatusBadRequest, 0) {
+		t.Fatal("4xx/5xx responses must always be sampled regardless of the bucket")
+	}
+}
+
+func TestBurstSamplerRefills(t *testing.T) {
+	bs := NewBurstSampler(1).(*burstSampler)
+	r := &http.Request{}
+
+	if !bs.Sample(r, http.StatusOK, 0) {
+		t.Fatal("expected the initial token to be available")
+	}
+	if bs.Sample(r, http.StatusOK, 0) {
+		t.Fatal("expected the bucket to be empty immediately after")
+	}
+
+	bs.last = bs.last.Add(-time.Second)
+	if !bs.Sample(r, http.StatusOK, 0) {
+		t.Fatal("expected the bucket to have refilled after a second elapsed")
+	}
+}
+
+func TestAdaptiveSamplerAlwaysLogsErrorsAndSlowRequests(t *testing.T) {
+	s := NewAdaptiveSampler(0.01)
+	r := &http.Request{}
+
+	if !s.Sample(r, http.StatusInternalServerError, 0) {
+		t.Fatal("5xx responses must always be sampled")
+	}
+	if !s.Sample(r, http.StatusOK, 2*time.Second) {
+		t.Fatal("requests slower than the slow threshold must always be sampled")
+	}
+}
+
+func TestAdaptiveSamplerSamplesFastSuccesses(t *testing.T) {
+	s := NewAdaptiveSampler(0.5)
+	r := &http.Request{}
+
+	var sampled int
+	for i := 0; i < 10; i++ {
+		if s.Sample(r, http.StatusOK, 0) {
+			sampled++
+		}
+	}
+	if sampled != 5 {
+		t.Fatalf("got %d sampled out of 10 at sampleRate 0.5, want 5", sampled)
+	}
+}
+
+func TestAdaptiveSamplerFullRateLogsEverything(t *testing.T) {
+	s := NewAdaptiveSampler(1)
+	r := &http.Request{}
+	for i := 0; i < 5; i++ {
+		if !s.Sample(r, http.StatusOK, 0) {
+			t.Fatal("sampleRate >= 1 should log every request")
+		}
+	}
+}
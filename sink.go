@@ -0,0 +1,25 @@
+package httplogx
+
+// Level is a log severity independent of any particular logging backend,
+// used to translate httplogx's status-derived levels into whatever scale
+// the underlying LogSink implementation understands.
+type Level int
+
+const (
+	LevelDebug Level = iota
+	LevelInfo
+	LevelWarn
+	LevelError
+)
+
+// LogSink is the logging backend abstraction RequestLoggerWithSink builds on.
+// Implementations adapt a concrete logging library (zerolog, slog, zap,
+// logrus, ...) to the minimal surface httplogx needs in order to emit
+// request/response log lines without importing that library directly.
+type LogSink interface {
+	// With returns a derived LogSink that includes fields on every
+	// subsequent call to Log.
+	With(fields map[string]interface{}) LogSink
+	// Log emits a single log line at the given level.
+	Log(level Level, msg string)
+}
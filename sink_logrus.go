@@ -0,0 +1,34 @@
+package httplogx
+
+import "github.com/sirupsen/logrus"
+
+// logrusSink adapts sirupsen/logrus to LogSink.
+type logrusSink struct {
+	entry *logrus.Entry
+}
+
+// NewLogrusSink wraps logger as a LogSink for use with RequestLoggerWithSink.
+func NewLogrusSink(logger *logrus.Logger) LogSink {
+	return &logrusSink{entry: logrus.NewEntry(logger)}
+}
+
+func (s *logrusSink) With(fields map[string]interface{}) LogSink {
+	return &logrusSink{entry: s.entry.WithFields(fields)}
+}
+
+func (s *logrusSink) Log(level Level, msg string) {
+	s.entry.Log(logrusLevel(level), msg)
+}
+
+func logrusLevel(level Level) logrus.Level {
+	switch level {
+	case LevelDebug:
+		return logrus.DebugLevel
+	case LevelWarn:
+		return logrus.WarnLevel
+	case LevelError:
+		return logrus.ErrorLevel
+	default:
+		return logrus.InfoLevel
+	}
+}
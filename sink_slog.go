@@ -0,0 +1,46 @@
+package httplogx
+
+import (
+	"context"
+	"log/slog"
+)
+
+// slogSink adapts log/slog to LogSink, letting users who've standardized on
+// the stdlib logger adopt httplogx without dragging in zerolog.
+type slogSink struct {
+	logger *slog.Logger
+}
+
+// NewSlogSink wraps logger as a LogSink for use with RequestLoggerWithSink.
+func NewSlogSink(logger *slog.Logger) LogSink {
+	return &slogSink{logger: logger}
+}
+
+func (s *slogSink) With(fields map[string]interface{}) LogSink {
+	return &slogSink{logger: s.logger.With(slogArgs(fields)...)}
+}
+
+func (s *slogSink) Log(level Level, msg string) {
+	s.logger.Log(context.Background(), slogLevel(level), msg)
+}
+
+func slogArgs(fields map[string]interface{}) []any {
+	args := make([]any, 0, len(fields)*2)
+	for k, v := range fields {
+		args = append(args, k, v)
+	}
+	return args
+}
+
+func slogLevel(level Level) slog.Level {
+	switch level {
+	case LevelDebug:
+		return slog.LevelDebug
+	case LevelWarn:
+		return slog.LevelWarn
+	case LevelError:
+		return slog.LevelError
+	default:
+		return slog.LevelInfo
+	}
+}
@@ -0,0 +1,45 @@
+package httplogx
+
+import (
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+// zapSink adapts go.uber.org/zap to LogSink.
+type zapSink struct {
+	logger *zap.Logger
+}
+
+// NewZapSink wraps logger as a LogSink for use with RequestLoggerWithSink.
+func NewZapSink(logger *zap.Logger) LogSink {
+	return &zapSink{logger: logger}
+}
+
+func (s *zapSink) With(fields map[string]interface{}) LogSink {
+	return &zapSink{logger: s.logger.With(zapFields(fields)...)}
+}
+
+func (s *zapSink) Log(level Level, msg string) {
+	s.logger.Check(zapLevel(level), msg).Write()
+}
+
+func zapFields(fields map[string]interface{}) []zap.Field {
+	zapFields := make([]zap.Field, 0, len(fields))
+	for k, v := range fields {
+		zapFields = append(zapFields, zap.Any(k, v))
+	}
+	return zapFields
+}
+
+func zapLevel(level Level) zapcore.Level {
+	switch level {
+	case LevelDebug:
+		return zapcore.DebugLevel
+	case LevelWarn:
+		return zapcore.WarnLevel
+	case LevelError:
+		return zapcore.ErrorLevel
+	default:
+		return zapcore.InfoLevel
+	}
+}
@@ -0,0 +1,35 @@
+package httplogx
+
+import "github.com/rs/zerolog"
+
+// zerologSink is the LogSink backed by zerolog.Logger that RequestLogger and
+// Chain wrap their zerolog.Logger in.
+type zerologSink struct {
+	logger zerolog.Logger
+}
+
+// NewZerologSink wraps logger as a LogSink for use with RequestLoggerWithSink.
+func NewZerologSink(logger zerolog.Logger) LogSink {
+	return &zerologSink{logger: logger}
+}
+
+func (s *zerologSink) With(fields map[string]interface{}) LogSink {
+	return &zerologSink{logger: s.logger.With().Fields(fields).Logger()}
+}
+
+func (s *zerologSink) Log(level Level, msg string) {
+	s.logger.WithLevel(zerologLevel(level)).Msg(msg)
+}
+
+func zerologLevel(level Level) zerolog.Level {
+	switch level {
+	case LevelDebug:
+		return zerolog.DebugLevel
+	case LevelWarn:
+		return zerolog.WarnLevel
+	case LevelError:
+		return zerolog.ErrorLevel
+	default:
+		return zerolog.InfoLevel
+	}
+}
@@ -0,0 +1,81 @@
+package httplogx
+
+import (
+	"context"
+	"encoding/hex"
+	"net/http"
+	"strings"
+)
+
+// TraceProvider extracts the active trace/span identifiers for a request,
+// letting users plug in OpenTelemetry (or any other tracer) without
+// httplogx importing it directly.
+type TraceProvider interface {
+	SpanContextFromContext(ctx context.Context) (traceID, spanID string, sampled bool, ok bool)
+}
+
+// traceFields returns trace_id, span_id, trace_flags and (if present)
+// trace_state as top-level fields for the given request, using provider if
+// set or falling back to parsing the W3C traceparent header otherwise. It
+// returns an empty map if no trace context is present.
+func traceFields(r *http.Request, provider TraceProvider) map[string]interface{} {
+	var traceID, spanID string
+	var sampled, ok bool
+
+	if provider != nil {
+		traceID, spanID, sampled, ok = provider.SpanContextFromContext(r.Context())
+	}
+	if !ok {
+		traceID, spanID, sampled, ok = parseTraceparent(r.Header.Get("traceparent"))
+	}
+	if !ok {
+		return map[string]interface{}{}
+	}
+
+	flags := "00"
+	if sampled {
+		flags = "01"
+	}
+
+	fields := map[string]interface{}{
+		"trace_id":    traceID,
+		"span_id":     spanID,
+		"trace_flags": flags,
+	}
+
+	// tracestate is vendor-specific, opaque key-value data accompanying
+	// traceparent; httplogx doesn't interpret it, just surfaces it
+	// verbatim so it can be joined against in the tracing backend.
+	if state := r.Header.Get("tracestate"); state != "" {
+		fields["trace_state"] = state
+	}
+
+	return fields
+}
+
+// parseTraceparent parses a W3C Trace Context "traceparent" header of the
+// form "version-trace_id-span_id-flags", e.g.
+// "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01".
+func parseTraceparent(header string) (traceID, spanID string, sampled, ok bool) {
+	parts := strings.Split(header, "-")
+	if len(parts) != 4 {
+		return "", "", false, false
+	}
+
+	version, traceID, spanID, flags := parts[0], parts[1], parts[2], parts[3]
+	if len(version) != 2 || len(traceID) != 32 || len(spanID) != 16 || len(flags) != 2 {
+		return "", "", false, false
+	}
+	if _, err := hex.DecodeString(traceID); err != nil {
+		return "", "", false, false
+	}
+	if _, err := hex.DecodeString(spanID); err != nil {
+		return "", "", false, false
+	}
+	flagsByte, err := hex.DecodeString(flags)
+	if err != nil {
+		return "", "", false, false
+	}
+
+	return traceID, spanID, flagsByte[0]&0x01 == 0x01, true
+}
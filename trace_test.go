@@ -0,0 +1,91 @@
+package httplogx
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestParseTraceparent(t *testing.T) {
+	cases := []struct {
+		name        string
+		header      string
+		wantTraceID string
+		wantSpanID  string
+		wantSampled bool
+		wantOK      bool
+	}{
+		{
+			name:        "sampled",
+			header:      "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01",
+			wantTraceID: "4bf92f3577b34da6a3ce929d0e0e4736",
+			wantSpanID:  "00f067aa0ba902b7",
+			wantSampled: true,
+			wantOK:      true,
+		},
+		{
+			name:        "not sampled",
+			header:      "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-00",
+			wantTraceID: "4bf92f3577b34da6a3ce929d0e0e4736",
+			wantSpanID:  "00f067aa0ba902b7",
+			wantSampled: false,
+			wantOK:      true,
+		},
+		{name: "empty", header: "", wantOK: false},
+		{name: "wrong segment count", header: "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7", wantOK: false},
+		{name: "short trace id", header: "00-deadbeef-00f067aa0ba902b7-01", wantOK: false},
+		{name: "short span id", header: "00-4bf92f3577b34da6a3ce929d0e0e4736-deadbeef-01", wantOK: false},
+		{name: "non-hex trace id", header: "00-zzzzzzzzzzzzzzzzzzzzzzzzzzzzzzzz-00f067aa0ba902b7-01", wantOK: false},
+		{name: "non-hex flags", header: "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-zz", wantOK: false},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			traceID, spanID, sampled, ok := parseTraceparent(tc.header)
+			if ok != tc.wantOK {
+				t.Fatalf("ok = %v, want %v", ok, tc.wantOK)
+			}
+			if !tc.wantOK {
+				return
+			}
+			if traceID != tc.wantTraceID {
+				t.Errorf("traceID = %q, want %q", traceID, tc.wantTraceID)
+			}
+			if spanID != tc.wantSpanID {
+				t.Errorf("spanID = %q, want %q", spanID, tc.wantSpanID)
+			}
+			if sampled != tc.wantSampled {
+				t.Errorf("sampled = %v, want %v", sampled, tc.wantSampled)
+			}
+		})
+	}
+}
+
+func TestTraceFieldsNoHeader(t *testing.T) {
+	r := httptest.NewRequest(http.MethodGet, "/", nil)
+	fields := traceFields(r, nil)
+	if len(fields) != 0 {
+		t.Fatalf("got %v, want an empty map when no trace context is present", fields)
+	}
+}
+
+func TestTraceFieldsIncludesTraceState(t *testing.T) {
+	r := httptest.NewRequest(http.MethodGet, "/", nil)
+	r.Header.Set("traceparent", "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01")
+	r.Header.Set("tracestate", "congo=t61rcWkgMzE")
+
+	fields := traceFields(r, nil)
+	if got := fields["trace_state"]; got != "congo=t61rcWkgMzE" {
+		t.Fatalf("trace_state = %v, want the raw tracestate header value", got)
+	}
+}
+
+func TestTraceFieldsOmitsTraceStateWhenAbsent(t *testing.T) {
+	r := httptest.NewRequest(http.MethodGet, "/", nil)
+	r.Header.Set("traceparent", "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01")
+
+	fields := traceFields(r, nil)
+	if _, ok := fields["trace_state"]; ok {
+		t.Fatalf("trace_state should be omitted when the tracestate header isn't set")
+	}
+}